@@ -0,0 +1,74 @@
+// Copyright © 2018 github.com/devopsctl authors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	cmderrors "github.com/huhouhua/gitlabctl/cmd/errors"
+)
+
+var deleteMrApprovalRuleCmd = &cobra.Command{
+	Use:               "mr-approval-rule",
+	Short:             "Delete an approval rule from a merge request",
+	SilenceErrors:     true,
+	SilenceUsage:      true,
+	DisableAutoGenTag: true,
+	Args:              cobra.ExactArgs(1),
+	Example: `# delete approval rule 123 from MR 42
+gitlabctl delete mr-approval-rule 123 --project=groupx/myapp --mr=42`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ruleID, err := strconv.Atoi(args[0])
+		if err != nil {
+			return err
+		}
+		project, err := promptRequiredProject(cmd, "project")
+		if err != nil {
+			return err
+		}
+		mr, err := getFlagInt(cmd, "mr")
+		if err != nil {
+			return err
+		}
+		return deleteMrApprovalRule(project, mr, ruleID)
+	},
+}
+
+func init() {
+	deleteCmd.AddCommand(deleteMrApprovalRuleCmd)
+	addProjectFlag(deleteMrApprovalRuleCmd)
+	addMergeRequestIIDFlag(deleteMrApprovalRuleCmd)
+	verifyMarkFlagRequired(deleteMrApprovalRuleCmd, "mr")
+}
+
+func deleteMrApprovalRule(project string, mr int, ruleID int) error {
+	git, err := newGitlabClient()
+	if err != nil {
+		return err
+	}
+	_, err = git.MergeRequestApprovals.DeleteApprovalRule(project, mr, ruleID)
+	if err != nil {
+		return cmderrors.FromAPIError(err)
+	}
+	return nil
+}
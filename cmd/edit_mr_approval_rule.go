@@ -0,0 +1,132 @@
+// Copyright © 2018 github.com/devopsctl authors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"strconv"
+
+	"github.com/spf13/cobra"
+	gitlab "github.com/xanzy/go-gitlab"
+
+	cmderrors "github.com/huhouhua/gitlabctl/cmd/errors"
+)
+
+var editMrApprovalRuleCmd = &cobra.Command{
+	Use:               "mr-approval-rule",
+	Short:             "Update an existing approval rule of a merge request",
+	SilenceErrors:     true,
+	SilenceUsage:      true,
+	DisableAutoGenTag: true,
+	Args:              cobra.ExactArgs(1),
+	Example: `# bump approval rule 123 on MR 42 to require 3 approvals
+gitlabctl edit mr-approval-rule 123 --project=groupx/myapp --mr=42 --approvals-required=3`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ruleID, err := strconv.Atoi(args[0])
+		if err != nil {
+			return err
+		}
+		return runEditMrApprovalRule(cmd, ruleID)
+	},
+}
+
+func init() {
+	editCmd.AddCommand(editMrApprovalRuleCmd)
+	addProjectFlag(editMrApprovalRuleCmd)
+	addMergeRequestIIDFlag(editMrApprovalRuleCmd)
+	verifyMarkFlagRequired(editMrApprovalRuleCmd, "mr")
+	addMrApprovalRuleFlags(editMrApprovalRuleCmd)
+}
+
+func runEditMrApprovalRule(cmd *cobra.Command, ruleID int) error {
+	opts, err := buildUpdateMrApprovalRuleOptions(cmd)
+	if err != nil {
+		return err
+	}
+	project, err := promptRequiredProject(cmd, "project")
+	if err != nil {
+		return err
+	}
+	mr, err := getFlagInt(cmd, "mr")
+	if err != nil {
+		return err
+	}
+	rule, err := editMrApprovalRule(project, mr, ruleID, opts)
+	if err != nil {
+		return err
+	}
+	out, err := getFlagString(cmd, "out")
+	if err != nil {
+		return err
+	}
+	return printApprovalRulesOut(out, rule)
+}
+
+// buildUpdateMrApprovalRuleOptions only sets the fields the user actually
+// passed on the command line. An approval rule's name and approver lists
+// are existing state, not defaults to overwrite on every edit - e.g.
+// `edit mr-approval-rule 123 --approvals-required=3` must not blank out
+// the rule's name or unset its approvers just because --name/--user-ids
+// were left at their zero values.
+func buildUpdateMrApprovalRuleOptions(cmd *cobra.Command) (*gitlab.UpdateMergeRequestApprovalRuleOptions, error) {
+	opts := &gitlab.UpdateMergeRequestApprovalRuleOptions{}
+	if cmd.Flags().Changed("name") {
+		name, err := getFlagString(cmd, "name")
+		if err != nil {
+			return nil, err
+		}
+		opts.Name = gitlab.String(name)
+	}
+	if cmd.Flags().Changed("approvals-required") {
+		approvalsRequired, err := getFlagInt(cmd, "approvals-required")
+		if err != nil {
+			return nil, err
+		}
+		opts.ApprovalsRequired = gitlab.Int(approvalsRequired)
+	}
+	if cmd.Flags().Changed("user-ids") {
+		userIDs, err := getFlagIntSlice(cmd, "user-ids")
+		if err != nil {
+			return nil, err
+		}
+		opts.UserIDs = gitlab.Ptr(userIDs)
+	}
+	if cmd.Flags().Changed("group-ids") {
+		groupIDs, err := getFlagIntSlice(cmd, "group-ids")
+		if err != nil {
+			return nil, err
+		}
+		opts.GroupIDs = gitlab.Ptr(groupIDs)
+	}
+	return opts, nil
+}
+
+func editMrApprovalRule(project string, mr int, ruleID int,
+	opts *gitlab.UpdateMergeRequestApprovalRuleOptions) (*gitlab.MergeRequestApprovalRule, error) {
+	git, err := newGitlabClient()
+	if err != nil {
+		return nil, err
+	}
+	rule, _, err := git.MergeRequestApprovals.UpdateApprovalRule(project, mr, ruleID, opts)
+	if err != nil {
+		return nil, cmderrors.FromAPIError(err)
+	}
+	return rule, nil
+}
@@ -0,0 +1,82 @@
+// Copyright © 2018 github.com/devopsctl authors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func newEditMrApprovalRuleTestCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: "mr-approval-rule"}
+	addMrApprovalRuleFlags(cmd)
+	return cmd
+}
+
+func TestBuildUpdateMrApprovalRuleOptionsOnlySendsChangedFlags(t *testing.T) {
+	cmd := newEditMrApprovalRuleTestCmd()
+
+	if err := cmd.Flags().Set("approvals-required", "3"); err != nil {
+		t.Fatalf("failed to set approvals-required: %v", err)
+	}
+
+	opts, err := buildUpdateMrApprovalRuleOptions(cmd)
+	if err != nil {
+		t.Fatalf("buildUpdateMrApprovalRuleOptions returned an error: %v", err)
+	}
+
+	if opts.ApprovalsRequired == nil || *opts.ApprovalsRequired != 3 {
+		t.Errorf("expected ApprovalsRequired=3, got %v", opts.ApprovalsRequired)
+	}
+	if opts.Name != nil {
+		t.Errorf("expected Name to be left unset, got %v", *opts.Name)
+	}
+	if opts.UserIDs != nil {
+		t.Errorf("expected UserIDs to be left unset, got %v", opts.UserIDs)
+	}
+	if opts.GroupIDs != nil {
+		t.Errorf("expected GroupIDs to be left unset, got %v", opts.GroupIDs)
+	}
+}
+
+func TestBuildUpdateMrApprovalRuleOptionsSendsAllChangedFlags(t *testing.T) {
+	cmd := newEditMrApprovalRuleTestCmd()
+
+	if err := cmd.Flags().Set("name", "Security Review"); err != nil {
+		t.Fatalf("failed to set name: %v", err)
+	}
+	if err := cmd.Flags().Set("user-ids", "1,2"); err != nil {
+		t.Fatalf("failed to set user-ids: %v", err)
+	}
+
+	opts, err := buildUpdateMrApprovalRuleOptions(cmd)
+	if err != nil {
+		t.Fatalf("buildUpdateMrApprovalRuleOptions returned an error: %v", err)
+	}
+
+	if opts.Name == nil || *opts.Name != "Security Review" {
+		t.Errorf("expected Name=%q, got %v", "Security Review", opts.Name)
+	}
+	if opts.UserIDs == nil || len(*opts.UserIDs) != 2 || (*opts.UserIDs)[0] != 1 || (*opts.UserIDs)[1] != 2 {
+		t.Errorf("expected UserIDs=[1 2], got %v", opts.UserIDs)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright © 2018 github.com/devopsctl authors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package errors
+
+import (
+	"errors"
+	"net/http"
+
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+// FromAPIError maps a go-gitlab error to a CmdError with a friendlier,
+// actionable message for the status codes users hit most often. Errors
+// that aren't a *gitlab.ErrorResponse (network failures, JSON decode
+// errors, ...) are wrapped as-is under CodeAPI.
+func FromAPIError(err error) *CmdError {
+	var apiErr *gitlab.ErrorResponse
+	if !errors.As(err, &apiErr) || apiErr.Response == nil {
+		return Wrap(CodeAPI, err, "GitLab API request failed")
+	}
+
+	switch apiErr.Response.StatusCode {
+	case http.StatusUnauthorized:
+		return Wrap(CodeAPI, err, "not authenticated with GitLab").
+			WithSuggestion("check that your access token is set and has not expired")
+	case http.StatusForbidden:
+		return Wrap(CodeAPI, err, "not authorized to perform this action").
+			WithSuggestion("check that your access token has the required scope and role")
+	case http.StatusNotFound:
+		return Wrap(CodeAPI, err, "resource not found").
+			WithSuggestion("check the project, group, or resource ID you passed")
+	default:
+		return Wrap(CodeAPI, err, "GitLab API request failed")
+	}
+}
+
+// ExitCode returns the process exit code a CmdError should produce: usage
+// errors exit distinctly from API errors so scripts can tell "you typed
+// it wrong" apart from "GitLab rejected it". Any other error (one that
+// isn't a *CmdError) falls back to a generic non-zero exit code.
+func ExitCode(err error) int {
+	var cmdErr *CmdError
+	if !errors.As(err, &cmdErr) {
+		return 1
+	}
+	switch cmdErr.Code {
+	case CodeUsage, CodeFlagAccess:
+		return 2
+	case CodeAPI:
+		return 3
+	default:
+		return 1
+	}
+}
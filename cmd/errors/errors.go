@@ -0,0 +1,86 @@
+// Copyright © 2018 github.com/devopsctl authors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package errors defines the typed error gitlabctl commands return instead
+// of calling glog.Fatalf, so commands can be driven programmatically (as a
+// library, or from tests) without killing the process.
+package errors
+
+import "fmt"
+
+// Code classifies a CmdError for exit-code and presentation purposes.
+type Code int
+
+const (
+	// CodeUnknown is the zero value; prefer a more specific code.
+	CodeUnknown Code = iota
+	// CodeUsage marks a problem with how the command was invoked (bad or
+	// missing flags, invalid arguments).
+	CodeUsage
+	// CodeFlagAccess marks a failure reading a flag that was registered
+	// on the command (a programming error, not a user one).
+	CodeFlagAccess
+	// CodeAPI marks an error returned by the GitLab API itself.
+	CodeAPI
+)
+
+// CmdError is the error type every gitlabctl command should return from
+// RunE. Code and Suggestion let the top-level error handler in main
+// decide the process exit code and what extra help to print, without
+// having to re-parse Error()'s text.
+type CmdError struct {
+	Code       Code
+	Message    string
+	Err        error
+	Suggestion string
+}
+
+func (e *CmdError) Error() string {
+	msg := e.Message
+	if e.Err != nil {
+		msg = fmt.Sprintf("%s: %v", msg, e.Err)
+	}
+	if e.Suggestion != "" {
+		msg = fmt.Sprintf("%s (%s)", msg, e.Suggestion)
+	}
+	return msg
+}
+
+// Unwrap lets errors.Is/errors.As see through a CmdError to the error it wraps.
+func (e *CmdError) Unwrap() error {
+	return e.Err
+}
+
+// New returns a CmdError with no wrapped error.
+func New(code Code, message string) *CmdError {
+	return &CmdError{Code: code, Message: message}
+}
+
+// Wrap returns a CmdError that carries err and reports message alongside it.
+func Wrap(code Code, err error, message string) *CmdError {
+	return &CmdError{Code: code, Message: message, Err: err}
+}
+
+// WithSuggestion attaches actionable follow-up text (e.g. "run `gitlabctl
+// config set token`") and returns e for chaining.
+func (e *CmdError) WithSuggestion(suggestion string) *CmdError {
+	e.Suggestion = suggestion
+	return e
+}
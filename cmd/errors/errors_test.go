@@ -0,0 +1,100 @@
+// Copyright © 2018 github.com/devopsctl authors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package errors
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	gitlab "github.com/xanzy/go-gitlab"
+)
+
+func apiError(statusCode int) *gitlab.ErrorResponse {
+	return &gitlab.ErrorResponse{
+		Response: &http.Response{
+			StatusCode: statusCode,
+			Request:    &http.Request{Method: http.MethodGet, URL: &url.URL{Scheme: "https", Host: "gitlab.example.com", Path: "/api/v4/projects/1"}},
+		},
+	}
+}
+
+func TestFromAPIErrorMapsKnownStatusCodesToSuggestions(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		wantSubstr string
+	}{
+		{"unauthorized", http.StatusUnauthorized, "not authenticated with GitLab"},
+		{"forbidden", http.StatusForbidden, "not authorized to perform this action"},
+		{"not found", http.StatusNotFound, "resource not found"},
+		{"other", http.StatusInternalServerError, "GitLab API request failed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmdErr := FromAPIError(apiError(tt.statusCode))
+			if cmdErr.Code != CodeAPI {
+				t.Errorf("Code = %v, want CodeAPI", cmdErr.Code)
+			}
+			if got := cmdErr.Error(); !strings.Contains(got, tt.wantSubstr) {
+				t.Errorf("Error() = %q, want it to contain %q", got, tt.wantSubstr)
+			}
+		})
+	}
+}
+
+// TestFromAPIErrorWrapsNonAPIErrors covers network failures and other
+// errors that aren't a *gitlab.ErrorResponse at all - FromAPIError must
+// still return a usable CmdError instead of panicking on the type assertion.
+func TestFromAPIErrorWrapsNonAPIErrors(t *testing.T) {
+	err := errors.New("connection refused")
+	cmdErr := FromAPIError(err)
+	if cmdErr.Code != CodeAPI {
+		t.Errorf("Code = %v, want CodeAPI", cmdErr.Code)
+	}
+	if !errors.Is(cmdErr, err) {
+		t.Errorf("FromAPIError should wrap the original error so errors.Is sees through it")
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"usage error", New(CodeUsage, "bad flag"), 2},
+		{"flag access error", New(CodeFlagAccess, "flag lookup failed"), 2},
+		{"API error", New(CodeAPI, "request failed"), 3},
+		{"unknown code", New(CodeUnknown, "something else"), 1},
+		{"not a CmdError", errors.New("plain error"), 1},
+		{"nil", nil, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
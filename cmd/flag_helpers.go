@@ -24,9 +24,12 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/golang/glog"
 	"github.com/spf13/cobra"
 	gitlab "github.com/xanzy/go-gitlab"
+
+	cmderrors "github.com/huhouhua/gitlabctl/cmd/errors"
+	"github.com/huhouhua/gitlabctl/cmd/printers"
+	"github.com/huhouhua/gitlabctl/cmd/prompt"
 )
 
 // addGetGroupsFlags adds common flags for `get groups` and `get subgroups` commands
@@ -205,6 +208,36 @@ func validateSortFlagValue(cmd *cobra.Command) error {
 		cmd, "sort")
 }
 
+// addPaginationFlags adds --page, --per-page, and --all to list commands.
+// --all takes precedence over --page: the command starts from --page and
+// keeps following gitlab.Response.NextPage until GitLab reports none left.
+func addPaginationFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("page", 1, "Page number to retrieve")
+	cmd.Flags().Int("per-page", 20, "Number of items to list per page")
+	cmd.Flags().Bool("all", false,
+		"Retrieve every page of results, following the API's next-page "+
+			"cursor until it is exhausted")
+}
+
+// addMergeRequestIIDFlag adds the --mr flag shared by every merge request
+// sub-resource command (approval rules, rebase, pipeline status, ...).
+func addMergeRequestIIDFlag(cmd *cobra.Command) {
+	cmd.Flags().Int("mr", 0, "The internal ID (IID) of the merge request")
+}
+
+// addMrApprovalRuleFlags adds the flags for `new mr-approval-rule` and
+// `edit mr-approval-rule`.
+// Flag usage reference: https://docs.gitlab.com/ce/api/merge_request_approvals.html
+func addMrApprovalRuleFlags(cmd *cobra.Command) {
+	cmd.Flags().String("name", "", "The name of the approval rule")
+	cmd.Flags().Int("approvals-required", 0,
+		"The number of required approvals for this rule")
+	cmd.Flags().IntSlice("user-ids", []int{},
+		"User IDs eligible to approve the merge request")
+	cmd.Flags().IntSlice("group-ids", []int{},
+		"Group IDs whose members are eligible to approve the merge request")
+}
+
 func addProjectOrderByFlag(cmd *cobra.Command) {
 	cmd.Flags().String("order-by", "created_at",
 		"Return projects ordered by id, name, path, created_at, updated_at, "+
@@ -256,27 +289,46 @@ func addPathFlag(cmd *cobra.Command) {
 }
 
 func addOutFlag(cmd *cobra.Command) {
-	cmd.PersistentFlags().StringP("out", "o", "simple",
-		"Print the command output to the "+
-			"desired format. (json, yaml, simple)")
+	cmd.PersistentFlags().StringP("out", "o", Simple,
+		"Print the command output to the desired format. One of: "+
+			strings.Join(printers.BuiltinFormats, ", "))
 }
 
 func validateOutFlagValue(cmd *cobra.Command) error {
-	return validateFlagStringValue([]string{JSON, YAML, "simple"},
-		cmd, "out")
+	out, err := getFlagString(cmd, "out")
+	if err != nil {
+		return err
+	}
+	if !printers.IsKnownFormat(out) {
+		return cmderrors.New(cmderrors.CodeUsage, fmt.Sprintf(
+			"'%s' is not a recognized value of 'out' flag. Please choose from: [%s]",
+			out, strings.Join(printers.BuiltinFormats, ", ")))
+	}
+	return nil
 }
 
 func validateFlagStringValue(stringSlice []string,
 	cmd *cobra.Command, fName string) error {
-	fValue := getFlagString(cmd, fName)
+	fValue, err := getFlagString(cmd, fName)
+	if err != nil {
+		return err
+	}
 	for _, v := range stringSlice {
 		if fValue == v {
 			return nil
 		}
 	}
-	return fmt.Errorf("'%s' is not a recognized value of '%s' flag. "+
-		"Please choose from: [%s]\n",
-		fValue, fName, strings.Join(stringSlice, ", "))
+	// At an interactive terminal, offer a select list built from the
+	// same allowed values instead of failing outright.
+	if interactiveEnabled(cmd) {
+		choice, err := prompt.Select(fmt.Sprintf("select a value for --%s", fName), stringSlice)
+		if err == nil {
+			return cmd.Flags().Set(fName, choice)
+		}
+	}
+	return cmderrors.New(cmderrors.CodeUsage, fmt.Sprintf(
+		"'%s' is not a recognized value of '%s' flag. Please choose from: [%s]",
+		fValue, fName, strings.Join(stringSlice, ", ")))
 }
 
 //
@@ -284,40 +336,164 @@ func validateFlagStringValue(stringSlice []string,
 //
 
 // getFlagVisibility converts the string flag visiblity to gitlab.VisibilityValue.
-func getFlagVisibility(cmd *cobra.Command) *gitlab.VisibilityValue {
-	v := getFlagString(cmd, "visibility")
-	return gitlab.Visibility(gitlab.VisibilityValue(v))
+func getFlagVisibility(cmd *cobra.Command) (*gitlab.VisibilityValue, error) {
+	v, err := getFlagString(cmd, "visibility")
+	if err != nil {
+		return nil, err
+	}
+	return gitlab.Visibility(gitlab.VisibilityValue(v)), nil
 }
 
 // getFlagMergeMethod converts the string flag merge-method to gitlab.MergeMethod
-func getFlagMergeMethod(cmd *cobra.Command) *gitlab.MergeMethodValue {
-	v := getFlagString(cmd, "merge-method")
-	return gitlab.MergeMethod(gitlab.MergeMethodValue(v))
+func getFlagMergeMethod(cmd *cobra.Command) (*gitlab.MergeMethodValue, error) {
+	v, err := getFlagString(cmd, "merge-method")
+	if err != nil {
+		return nil, err
+	}
+	return gitlab.MergeMethod(gitlab.MergeMethodValue(v)), nil
+}
+
+// flagAccessError wraps a pflag lookup failure as a CmdError. A lookup
+// only fails when the flag was never registered on cmd, or was registered
+// with a different type, which is a programming error rather than something the user can fix, but it still
+// needs to propagate through RunE instead of killing the process.
+func flagAccessError(cmd *cobra.Command, flag string, err error) error {
+	return cmderrors.Wrap(cmderrors.CodeFlagAccess, err,
+		fmt.Sprintf("error accessing flag %q for command %q", flag, cmd.Name()))
 }
 
-func getFlagStringSlice(cmd *cobra.Command, flag string) []string {
+func getFlagStringSlice(cmd *cobra.Command, flag string) ([]string, error) {
 	s, err := cmd.Flags().GetStringSlice(flag)
 	if err != nil {
-		glog.Fatalf("error accessing flag %s for command %s: %v",
-			flag, cmd.Name(), err)
+		return nil, flagAccessError(cmd, flag, err)
 	}
-	return s
+	return s, nil
 }
 
-func getFlagString(cmd *cobra.Command, flag string) string {
+func getFlagString(cmd *cobra.Command, flag string) (string, error) {
 	s, err := cmd.Flags().GetString(flag)
 	if err != nil {
-		glog.Fatalf("error accessing flag %s for command %s: %v",
-			flag, cmd.Name(), err)
+		return "", flagAccessError(cmd, flag, err)
 	}
-	return s
+	return s, nil
 }
 
-func getFlagBool(cmd *cobra.Command, flag string) bool {
+func getFlagBool(cmd *cobra.Command, flag string) (bool, error) {
 	b, err := cmd.Flags().GetBool(flag)
 	if err != nil {
-		glog.Fatalf("error accessing flag %s for command %s: %v",
-			flag, cmd.Name(), err)
+		return false, flagAccessError(cmd, flag, err)
+	}
+	return b, nil
+}
+
+func getFlagIntSlice(cmd *cobra.Command, flag string) ([]int, error) {
+	s, err := cmd.Flags().GetIntSlice(flag)
+	if err != nil {
+		return nil, flagAccessError(cmd, flag, err)
+	}
+	return s, nil
+}
+
+func getFlagInt(cmd *cobra.Command, flag string) (int, error) {
+	i, err := cmd.Flags().GetInt(flag)
+	if err != nil {
+		return 0, flagAccessError(cmd, flag, err)
+	}
+	return i, nil
+}
+
+// assignListProjectOptions builds a gitlab.ListProjectsOptions from the
+// flags added by addGetProjectsFlags and addPaginationFlags.
+func assignListProjectOptions(cmd *cobra.Command) (*gitlab.ListProjectsOptions, error) {
+	page, err := getFlagInt(cmd, "page")
+	if err != nil {
+		return nil, err
+	}
+	perPage, err := getFlagInt(cmd, "per-page")
+	if err != nil {
+		return nil, err
+	}
+	archived, err := getFlagBool(cmd, "archived")
+	if err != nil {
+		return nil, err
+	}
+	visibility, err := getFlagVisibility(cmd)
+	if err != nil {
+		return nil, err
+	}
+	orderBy, err := getFlagString(cmd, "order-by")
+	if err != nil {
+		return nil, err
+	}
+	sort, err := getFlagString(cmd, "sort")
+	if err != nil {
+		return nil, err
+	}
+	search, err := getFlagString(cmd, "search")
+	if err != nil {
+		return nil, err
+	}
+	simple, err := getFlagBool(cmd, "simple")
+	if err != nil {
+		return nil, err
+	}
+	owned, err := getFlagBool(cmd, "owned")
+	if err != nil {
+		return nil, err
+	}
+	membership, err := getFlagBool(cmd, "membership")
+	if err != nil {
+		return nil, err
+	}
+	starred, err := getFlagBool(cmd, "starred")
+	if err != nil {
+		return nil, err
+	}
+	statistics, err := getFlagBool(cmd, "statistics")
+	if err != nil {
+		return nil, err
+	}
+	withIssuesEnabled, err := getFlagBool(cmd, "with-issues-enabled")
+	if err != nil {
+		return nil, err
+	}
+	withMergeRequestsEnabled, err := getFlagBool(cmd, "with-merge-requests-enabled")
+	if err != nil {
+		return nil, err
+	}
+	return &gitlab.ListProjectsOptions{
+		ListOptions:              gitlab.ListOptions{Page: page, PerPage: perPage},
+		Archived:                 gitlab.Bool(archived),
+		Visibility:               visibility,
+		OrderBy:                  gitlab.String(orderBy),
+		Sort:                     gitlab.String(sort),
+		Search:                   gitlab.String(search),
+		Simple:                   gitlab.Bool(simple),
+		Owned:                    gitlab.Bool(owned),
+		Membership:               gitlab.Bool(membership),
+		Starred:                  gitlab.Bool(starred),
+		Statistics:               gitlab.Bool(statistics),
+		WithIssuesEnabled:        gitlab.Bool(withIssuesEnabled),
+		WithMergeRequestsEnabled: gitlab.Bool(withMergeRequestsEnabled),
+	}, nil
+}
+
+// paginate calls fetch with successive page numbers, starting at
+// startPage. fetch is expected to print/consume its own page of results
+// (so callers stream rather than buffer every page in memory) and return
+// the *gitlab.Response it got back. Pagination stops after the first call
+// unless all is true, in which case it continues until the response
+// reports no NextPage.
+func paginate(startPage int, all bool, fetch func(page int) (*gitlab.Response, error)) error {
+	page := startPage
+	for {
+		resp, err := fetch(page)
+		if err != nil {
+			return err
+		}
+		if !all || resp == nil || resp.NextPage == 0 {
+			return nil
+		}
+		page = resp.NextPage
 	}
-	return b
 }
@@ -0,0 +1,81 @@
+// Copyright © 2018 github.com/devopsctl authors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	gitlab "github.com/xanzy/go-gitlab"
+
+	cmderrors "github.com/huhouhua/gitlabctl/cmd/errors"
+)
+
+var getMrApprovalRuleCmd = &cobra.Command{
+	Use:               "mr-approval-rule",
+	Aliases:           []string{"mr-approval-rules"},
+	Short:             "List the approval rules of a merge request",
+	SilenceErrors:     true,
+	SilenceUsage:      true,
+	DisableAutoGenTag: true,
+	Example: `# list the approval rules of an MR, with who has/can approve
+gitlabctl get mr-approval-rule --project=groupx/myapp --mr=42`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runGetMrApprovalRule(cmd)
+	},
+}
+
+func init() {
+	getCmd.AddCommand(getMrApprovalRuleCmd)
+	addProjectFlag(getMrApprovalRuleCmd)
+	addMergeRequestIIDFlag(getMrApprovalRuleCmd)
+	verifyMarkFlagRequired(getMrApprovalRuleCmd, "mr")
+}
+
+func runGetMrApprovalRule(cmd *cobra.Command) error {
+	project, err := promptRequiredProject(cmd, "project")
+	if err != nil {
+		return err
+	}
+	mr, err := getFlagInt(cmd, "mr")
+	if err != nil {
+		return err
+	}
+	state, err := getMrApprovalState(project, mr)
+	if err != nil {
+		return err
+	}
+	out, err := getFlagString(cmd, "out")
+	if err != nil {
+		return err
+	}
+	return printApprovalRulesOut(out, state.Rules...)
+}
+
+func getMrApprovalState(project string, mr int) (*gitlab.MergeRequestApprovalState, error) {
+	git, err := newGitlabClient()
+	if err != nil {
+		return nil, err
+	}
+	state, _, err := git.MergeRequestApprovals.GetApprovalState(project, mr)
+	if err != nil {
+		return nil, cmderrors.FromAPIError(err)
+	}
+	return state, nil
+}
@@ -0,0 +1,113 @@
+// Copyright © 2018 github.com/devopsctl authors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	gitlab "github.com/xanzy/go-gitlab"
+
+	cmderrors "github.com/huhouhua/gitlabctl/cmd/errors"
+	"github.com/huhouhua/gitlabctl/cmd/printers"
+)
+
+var getMrPipelineCmd = &cobra.Command{
+	Use:               "mr-pipeline <id>",
+	Aliases:           []string{"mr-pipelines"},
+	Short:             "List the pipelines run against a merge request's HEAD commit",
+	SilenceErrors:     true,
+	SilenceUsage:      true,
+	DisableAutoGenTag: true,
+	Args:              cobra.ExactArgs(1),
+	Example: `# pre-merge triage: what's the CI status of MR 42?
+gitlabctl get mr-pipeline 42 --project=groupx/myapp`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mr, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid merge request IID %q: %w", args[0], err)
+		}
+		return runGetMrPipeline(cmd, mr)
+	},
+}
+
+func init() {
+	getCmd.AddCommand(getMrPipelineCmd)
+	addProjectFlag(getMrPipelineCmd)
+}
+
+// mrPipelineJob is one row of `get mr-pipeline` output: a single CI job
+// belonging to one of the pipelines run against the MR's HEAD SHA.
+type mrPipelineJob struct {
+	PipelineID int
+	JobName    string
+	Stage      string
+	Status     string
+}
+
+type mrPipelineJobList []mrPipelineJob
+
+func (l mrPipelineJobList) ToTable() printers.Table {
+	t := printers.Table{Headers: []string{"PIPELINE", "JOB", "STAGE", "STATUS"}}
+	for _, j := range l {
+		t.Rows = append(t.Rows, []string{fmt.Sprintf("%d", j.PipelineID), j.JobName, j.Stage, j.Status})
+	}
+	return t
+}
+
+func runGetMrPipeline(cmd *cobra.Command, mr int) error {
+	project, err := promptRequiredProject(cmd, "project")
+	if err != nil {
+		return err
+	}
+	git, err := newGitlabClient()
+	if err != nil {
+		return err
+	}
+	mergeRequest, _, err := git.MergeRequests.GetMergeRequest(project, mr, nil)
+	if err != nil {
+		return cmderrors.FromAPIError(err)
+	}
+	sha := mergeRequest.SHA
+	pipelines, _, err := git.Pipelines.ListProjectPipelines(project, &gitlab.ListProjectPipelinesOptions{
+		SHA: gitlab.String(sha),
+	})
+	if err != nil {
+		return cmderrors.FromAPIError(err)
+	}
+
+	var jobs mrPipelineJobList
+	for _, pipeline := range pipelines {
+		pipelineJobs, _, err := git.Jobs.ListPipelineJobs(project, pipeline.ID, nil)
+		if err != nil {
+			return fmt.Errorf("fetching jobs for pipeline %d: %w", pipeline.ID, cmderrors.FromAPIError(err))
+		}
+		for _, j := range pipelineJobs {
+			jobs = append(jobs, mrPipelineJob{PipelineID: pipeline.ID, JobName: j.Name, Stage: j.Stage, Status: j.Status})
+		}
+	}
+	out, err := getFlagString(cmd, "out")
+	if err != nil {
+		return err
+	}
+	return printOut(out, jobs)
+}
@@ -0,0 +1,170 @@
+// Copyright © 2018 github.com/devopsctl authors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	gitlab "github.com/xanzy/go-gitlab"
+
+	cmderrors "github.com/huhouhua/gitlabctl/cmd/errors"
+	"github.com/huhouhua/gitlabctl/cmd/prompt"
+)
+
+func init() {
+	rootCmd.PersistentFlags().Bool("no-interactive", false,
+		"Disable interactive prompts for missing or invalid flags, "+
+			"even when stdin is a terminal")
+}
+
+// interactiveEnabled reports whether cmd may fall back to an interactive
+// prompt instead of failing outright: stdin must be a terminal and the
+// user must not have passed --no-interactive.
+func interactiveEnabled(cmd *cobra.Command) bool {
+	noInteractive, err := getFlagBool(cmd, "no-interactive")
+	if err != nil || noInteractive {
+		return false
+	}
+	return prompt.IsTTY()
+}
+
+// requiredFlagError mirrors the message cobra itself produces for a
+// missing MarkFlagRequired flag, so scripted (non-interactive) callers
+// see the same error whether or not the flag went through the
+// interactive fallback below.
+func requiredFlagError(flag string) error {
+	return cmderrors.New(cmderrors.CodeUsage, fmt.Sprintf(`required flag(s) "%s" not set`, flag))
+}
+
+// promptRequiredEditorString returns the current value of flag,
+// opening $EDITOR for multi-line input when it is unset and an
+// interactive terminal is attached, in the same way `git commit` falls
+// back to an editor. It replaces verifyMarkFlagRequired for free-form
+// text flags such as a release description.
+func promptRequiredEditorString(cmd *cobra.Command, flag, label string) (string, error) {
+	value, err := getFlagString(cmd, flag)
+	if err != nil {
+		return "", err
+	}
+	if value != "" {
+		return value, nil
+	}
+	if !interactiveEnabled(cmd) {
+		return "", requiredFlagError(flag)
+	}
+	value, err = prompt.Editor(fmt.Sprintf("# %s\n", label))
+	if err != nil {
+		return "", err
+	}
+	if err := cmd.Flags().Set(flag, value); err != nil {
+		return "", flagAccessError(cmd, flag, err)
+	}
+	return value, nil
+}
+
+// promptRequiredProject returns the current value of flag, prompting
+// for one when it is unset and an interactive terminal is attached. It
+// offers the project paths cached by a prior `get projects` call as
+// completions.
+func promptRequiredProject(cmd *cobra.Command, flag string) (string, error) {
+	value, err := getFlagString(cmd, flag)
+	if err != nil {
+		return "", err
+	}
+	if value != "" {
+		return value, nil
+	}
+	if !interactiveEnabled(cmd) {
+		return "", requiredFlagError(flag)
+	}
+	value, err = prompt.Complete("project", loadProjectCache())
+	if err != nil {
+		return "", err
+	}
+	if err := cmd.Flags().Set(flag, value); err != nil {
+		return "", flagAccessError(cmd, flag, err)
+	}
+	return value, nil
+}
+
+const projectCacheFileName = "gitlabctl-projects-cache"
+
+func projectCachePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, projectCacheFileName), nil
+}
+
+// loadProjectCache reads back the project paths saved by cacheProjectPaths.
+// It returns nil, without error, when no cache exists yet.
+func loadProjectCache() []string {
+	path, err := projectCachePath()
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var paths []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			paths = append(paths, line)
+		}
+	}
+	return paths
+}
+
+// cacheProjectPaths best-effort records the paths of projects seen in a
+// `get projects` response, merged with whatever was already cached, so a
+// later interactive --project prompt can offer them as completions.
+// Failures are ignored: this is a convenience, not something a command
+// should fail over.
+func cacheProjectPaths(projects []*gitlab.Project) {
+	seen := make(map[string]bool)
+	var paths []string
+	for _, p := range loadProjectCache() {
+		if !seen[p] {
+			seen[p] = true
+			paths = append(paths, p)
+		}
+	}
+	for _, p := range projects {
+		if p.PathWithNamespace != "" && !seen[p.PathWithNamespace] {
+			seen[p.PathWithNamespace] = true
+			paths = append(paths, p.PathWithNamespace)
+		}
+	}
+	path, err := projectCachePath()
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, []byte(strings.Join(paths, "\n")), 0o600)
+}
@@ -0,0 +1,163 @@
+// Copyright © 2018 github.com/devopsctl authors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	gitlab "github.com/xanzy/go-gitlab"
+
+	cmderrors "github.com/huhouhua/gitlabctl/cmd/errors"
+)
+
+var mrForIssueCmd = &cobra.Command{
+	Use:               "for <issue-iid>",
+	Short:             "Create a merge request from an issue",
+	SilenceErrors:     true,
+	SilenceUsage:      true,
+	DisableAutoGenTag: true,
+	Args:              cobra.ExactArgs(1),
+	Example: `# start work on issue 42: branch + MR, linked back via "Closes #42"
+gitlabctl mr for 42 --project=groupx/myapp`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		issueIID, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid issue IID %q: %w", args[0], err)
+		}
+		return runMrForIssue(cmd, issueIID)
+	},
+}
+
+func init() {
+	mrCmd.AddCommand(mrForIssueCmd)
+	addProjectFlag(mrForIssueCmd)
+	mrForIssueCmd.Flags().String("target-branch", "",
+		"Branch to target the merge request at "+
+			"(defaults to the project's default branch)")
+	mrForIssueCmd.Flags().Bool("wip", false,
+		"Prefix the merge request title with \"WIP:\" (--draft takes precedence)")
+	mrForIssueCmd.Flags().Bool("draft", false,
+		"Prefix the merge request title with \"Draft:\"")
+	mrForIssueCmd.Flags().Int("assignee", 0, "User ID to assign the merge request to")
+	mrForIssueCmd.Flags().Int("milestone", 0, "Milestone ID to attach to the merge request")
+	mrForIssueCmd.Flags().StringSlice("labels", []string{}, "Labels to apply to the merge request")
+	mrForIssueCmd.Flags().Bool("remove-source-branch", false,
+		"Remove the source branch when the merge request is merged")
+}
+
+func runMrForIssue(cmd *cobra.Command, issueIID int) error {
+	project, err := promptRequiredProject(cmd, "project")
+	if err != nil {
+		return err
+	}
+	git, err := newGitlabClient()
+	if err != nil {
+		return err
+	}
+	issue, _, err := git.Issues.GetIssue(project, issueIID)
+	if err != nil {
+		return cmderrors.FromAPIError(err)
+	}
+	p, _, err := git.Projects.GetProject(project, nil)
+	if err != nil {
+		return cmderrors.FromAPIError(err)
+	}
+	targetBranch, err := getFlagString(cmd, "target-branch")
+	if err != nil {
+		return err
+	}
+	if targetBranch == "" {
+		targetBranch = p.DefaultBranch
+	}
+	sourceBranch := fmt.Sprintf("%d-%s", issue.IID, slugify(issue.Title))
+	if _, _, err := git.Branches.CreateBranch(project, &gitlab.CreateBranchOptions{
+		Branch: gitlab.String(sourceBranch),
+		Ref:    gitlab.String(targetBranch),
+	}); err != nil {
+		return fmt.Errorf("creating branch %q: %w", sourceBranch, cmderrors.FromAPIError(err))
+	}
+
+	draft, err := getFlagBool(cmd, "draft")
+	if err != nil {
+		return err
+	}
+	wip, err := getFlagBool(cmd, "wip")
+	if err != nil {
+		return err
+	}
+	title := issue.Title
+	if draft {
+		title = "Draft: " + title
+	} else if wip {
+		title = "WIP: " + title
+	}
+
+	labels, err := getFlagStringSlice(cmd, "labels")
+	if err != nil {
+		return err
+	}
+	removeSourceBranch, err := getFlagBool(cmd, "remove-source-branch")
+	if err != nil {
+		return err
+	}
+	opts := &gitlab.CreateMergeRequestOptions{
+		Title:              gitlab.String(title),
+		Description:        gitlab.String(fmt.Sprintf("Closes #%d", issue.IID)),
+		SourceBranch:       gitlab.String(sourceBranch),
+		TargetBranch:       gitlab.String(targetBranch),
+		Labels:             gitlab.Ptr(gitlab.LabelOptions(labels)),
+		RemoveSourceBranch: gitlab.Bool(removeSourceBranch),
+	}
+	if assignee, err := getFlagInt(cmd, "assignee"); err != nil {
+		return err
+	} else if assignee != 0 {
+		opts.AssigneeID = gitlab.Int(assignee)
+	}
+	if milestone, err := getFlagInt(cmd, "milestone"); err != nil {
+		return err
+	} else if milestone != 0 {
+		opts.MilestoneID = gitlab.Int(milestone)
+	}
+
+	mr, _, err := git.MergeRequests.CreateMergeRequest(project, opts)
+	if err != nil {
+		return fmt.Errorf("creating merge request from branch %q: %w", sourceBranch, cmderrors.FromAPIError(err))
+	}
+	out, err := getFlagString(cmd, "out")
+	if err != nil {
+		return err
+	}
+	return printMergeRequestsOut(out, mr)
+}
+
+var slugInvalidChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// slugify lowercases s and replaces runs of non-alphanumeric characters
+// with a single hyphen, trimming any leading/trailing hyphen, so an issue
+// title can be used as (part of) a git branch name.
+func slugify(s string) string {
+	slug := slugInvalidChars.ReplaceAllString(strings.ToLower(s), "-")
+	return strings.Trim(slug, "-")
+}
@@ -0,0 +1,106 @@
+// Copyright © 2018 github.com/devopsctl authors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	gitlab "github.com/xanzy/go-gitlab"
+
+	cmderrors "github.com/huhouhua/gitlabctl/cmd/errors"
+)
+
+var newMrApprovalRuleCmd = &cobra.Command{
+	Use:               "mr-approval-rule",
+	Short:             "Create a new approval rule for a merge request",
+	SilenceErrors:     true,
+	SilenceUsage:      true,
+	DisableAutoGenTag: true,
+	Example: `# require 2 approvals from a specific group before merge
+gitlabctl new mr-approval-rule --project=groupx/myapp --mr=42 \
+  --name="Security Review" --approvals-required=2 --group-ids=7`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runNewMrApprovalRule(cmd)
+	},
+}
+
+func init() {
+	newCmd.AddCommand(newMrApprovalRuleCmd)
+	addProjectFlag(newMrApprovalRuleCmd)
+	addMergeRequestIIDFlag(newMrApprovalRuleCmd)
+	verifyMarkFlagRequired(newMrApprovalRuleCmd, "mr")
+	addMrApprovalRuleFlags(newMrApprovalRuleCmd)
+	verifyMarkFlagRequired(newMrApprovalRuleCmd, "name")
+}
+
+func runNewMrApprovalRule(cmd *cobra.Command) error {
+	name, err := getFlagString(cmd, "name")
+	if err != nil {
+		return err
+	}
+	approvalsRequired, err := getFlagInt(cmd, "approvals-required")
+	if err != nil {
+		return err
+	}
+	userIDs, err := getFlagIntSlice(cmd, "user-ids")
+	if err != nil {
+		return err
+	}
+	groupIDs, err := getFlagIntSlice(cmd, "group-ids")
+	if err != nil {
+		return err
+	}
+	opts := &gitlab.CreateMergeRequestApprovalRuleOptions{
+		Name:              gitlab.String(name),
+		ApprovalsRequired: gitlab.Int(approvalsRequired),
+		UserIDs:           gitlab.Ptr(userIDs),
+		GroupIDs:          gitlab.Ptr(groupIDs),
+	}
+	project, err := promptRequiredProject(cmd, "project")
+	if err != nil {
+		return err
+	}
+	mr, err := getFlagInt(cmd, "mr")
+	if err != nil {
+		return err
+	}
+	rule, err := newMrApprovalRule(project, mr, opts)
+	if err != nil {
+		return err
+	}
+	out, err := getFlagString(cmd, "out")
+	if err != nil {
+		return err
+	}
+	return printApprovalRulesOut(out, rule)
+}
+
+func newMrApprovalRule(project string, mr int,
+	opts *gitlab.CreateMergeRequestApprovalRuleOptions) (*gitlab.MergeRequestApprovalRule, error) {
+	git, err := newGitlabClient()
+	if err != nil {
+		return nil, err
+	}
+	rule, _, err := git.MergeRequestApprovals.CreateApprovalRule(project, mr, opts)
+	if err != nil {
+		return nil, cmderrors.FromAPIError(err)
+	}
+	return rule, nil
+}
@@ -22,6 +22,8 @@ package cmd
 import (
 	"github.com/spf13/cobra"
 	gitlab "github.com/xanzy/go-gitlab"
+
+	cmderrors "github.com/huhouhua/gitlabctl/cmd/errors"
 )
 
 var newReleaseCmd = &cobra.Command{
@@ -45,21 +47,32 @@ gitlabctl new release v1.0 --project=groupx/myapp --description="Sample Release
 func init() {
 	newCmd.AddCommand(newReleaseCmd)
 	addProjectFlag(newReleaseCmd)
-	verifyMarkFlagRequired(newReleaseCmd, "project")
 	newReleaseCmd.Flags().StringP("description", "d", "",
 		"The release note or description")
-	verifyMarkFlagRequired(newReleaseCmd, "description")
 }
 
 func runNewRelease(cmd *cobra.Command, tag string) error {
+	// project and description fall back to an interactive prompt when
+	// unset and a terminal is attached, rather than failing outright.
+	description, err := promptRequiredEditorString(cmd, "description", "release description")
+	if err != nil {
+		return err
+	}
+	project, err := promptRequiredProject(cmd, "project")
+	if err != nil {
+		return err
+	}
 	opts := new(gitlab.CreateReleaseOptions)
-	opts.Description = gitlab.String(getFlagString(cmd, "description"))
-	createdRelease, err := newRelease(getFlagString(cmd, "project"), tag, opts)
+	opts.Description = gitlab.String(description)
+	createdRelease, err := newRelease(project, tag, opts)
 	if err != nil {
 		return err
 	}
-	printReleasesOut(getFlagString(cmd, "out"), createdRelease)
-	return nil
+	out, err := getFlagString(cmd, "out")
+	if err != nil {
+		return err
+	}
+	return printReleasesOut(out, createdRelease)
 }
 
 func newRelease(project string, tag string, opts *gitlab.CreateReleaseOptions) (*gitlab.Release, error) {
@@ -70,7 +83,7 @@ func newRelease(project string, tag string, opts *gitlab.CreateReleaseOptions) (
 	opts.TagName = &tag
 	release, _, err := git.Releases.CreateRelease(project, opts)
 	if err != nil {
-		return nil, err
+		return nil, cmderrors.FromAPIError(err)
 	}
 	return release, nil
 }
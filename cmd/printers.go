@@ -0,0 +1,242 @@
+// Copyright © 2018 github.com/devopsctl authors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	gitlab "github.com/xanzy/go-gitlab"
+
+	cmderrors "github.com/huhouhua/gitlabctl/cmd/errors"
+	"github.com/huhouhua/gitlabctl/cmd/printers"
+)
+
+// JSON, YAML, Simple and Wide mirror the printers package's built-in
+// format names so call sites in this package don't need to import
+// printers just to refer to them (e.g. addOutFlag's default of Simple).
+const (
+	JSON   = printers.JSON
+	YAML   = printers.YAML
+	Simple = printers.Simple
+	Wide   = printers.Wide
+)
+
+// printOut looks up out in the printers.DefaultRegistry and prints v to
+// stdout, returning a CmdError on either an unrecognized format or a
+// rendering error instead of killing the process. Every printFooOut
+// helper is a thin wrapper around this.
+func printOut(out string, v interface{}) error {
+	p, err := printers.DefaultRegistry.Get(out)
+	if err != nil {
+		return cmderrors.New(cmderrors.CodeUsage, err.Error())
+	}
+	if err := p.PrintObj(os.Stdout, v); err != nil {
+		return cmderrors.Wrap(cmderrors.CodeUnknown, err, "error printing output")
+	}
+	return nil
+}
+
+// printStream drives a printers.Printer across however many pages a
+// paginated list command (e.g. `get projects --all`) fetches. Formats
+// that implement printers.StreamPrinter get their framing - a JSON
+// array's brackets, a table's header row - exactly once for the whole
+// run; formats that don't (go-template, jsonpath, ...) have no partial
+// form, so their pages are buffered and printed as one PrintObj call in
+// Close instead.
+type printStream struct {
+	p        printers.Printer
+	sp       printers.StreamPrinter
+	opened   bool
+	buffered []interface{}
+}
+
+// newPrintStream looks up out in the printers.DefaultRegistry the same
+// way printOut does, but returns a *printStream instead of printing
+// immediately, so the caller can feed it one page at a time.
+func newPrintStream(out string) (*printStream, error) {
+	p, err := printers.DefaultRegistry.Get(out)
+	if err != nil {
+		return nil, cmderrors.New(cmderrors.CodeUsage, err.Error())
+	}
+	ps := &printStream{p: p}
+	ps.sp, _ = p.(printers.StreamPrinter)
+	return ps, nil
+}
+
+// Write prints (or buffers) one page's worth of items, e.g. a
+// projectList wrapping a single page of *gitlab.Project.
+func (ps *printStream) Write(items interface{}) error {
+	if ps.sp == nil {
+		v := reflect.ValueOf(items)
+		for i := 0; i < v.Len(); i++ {
+			ps.buffered = append(ps.buffered, v.Index(i).Interface())
+		}
+		return nil
+	}
+	if !ps.opened {
+		if err := ps.sp.Open(os.Stdout); err != nil {
+			return err
+		}
+		ps.opened = true
+	}
+	return ps.sp.WriteItems(os.Stdout, items)
+}
+
+// Close must be called exactly once, after the last page has been
+// written, to flush whatever framing or buffered output is outstanding.
+func (ps *printStream) Close() error {
+	if ps.sp == nil {
+		return ps.p.PrintObj(os.Stdout, ps.buffered)
+	}
+	if !ps.opened {
+		if err := ps.sp.Open(os.Stdout); err != nil {
+			return err
+		}
+	}
+	return ps.sp.Close(os.Stdout)
+}
+
+// projectList adapts a slice of gitlab.Project for the printers package:
+// it marshals to JSON/YAML like a plain slice would, and additionally
+// renders as a simple or wide table.
+type projectList []*gitlab.Project
+
+func printProjectsOut(out string, projects ...*gitlab.Project) error {
+	return printOut(out, projectList(projects))
+}
+
+func (l projectList) ToTable() printers.Table {
+	t := printers.Table{Headers: []string{"ID", "NAME", "PATH", "VISIBILITY"}}
+	for _, p := range l {
+		t.Rows = append(t.Rows, []string{
+			fmt.Sprintf("%d", p.ID), p.Name, p.PathWithNamespace, string(p.Visibility),
+		})
+	}
+	return t
+}
+
+func (l projectList) ToWideTable() printers.Table {
+	t := l.ToTable()
+	t.Headers = append(t.Headers, "DEFAULT-BRANCH", "WEB-URL")
+	for i, p := range l {
+		t.Rows[i] = append(t.Rows[i], p.DefaultBranch, p.WebURL)
+	}
+	return t
+}
+
+// releaseList is the printers.TableConvertible adapter for gitlab.Release.
+type releaseList []*gitlab.Release
+
+func printReleasesOut(out string, releases ...*gitlab.Release) error {
+	return printOut(out, releaseList(releases))
+}
+
+func (l releaseList) ToTable() printers.Table {
+	t := printers.Table{Headers: []string{"TAG", "NAME", "DESCRIPTION"}}
+	for _, r := range l {
+		t.Rows = append(t.Rows, []string{r.TagName, r.Name, r.Description})
+	}
+	return t
+}
+
+func (l releaseList) ToWideTable() printers.Table {
+	t := l.ToTable()
+	t.Headers = append(t.Headers, "RELEASED-AT")
+	for i, r := range l {
+		releasedAt := ""
+		if r.ReleasedAt != nil {
+			releasedAt = r.ReleasedAt.String()
+		}
+		t.Rows[i] = append(t.Rows[i], releasedAt)
+	}
+	return t
+}
+
+// approvalRuleList is the printers.TableConvertible adapter for
+// gitlab.MergeRequestApprovalRule, shaped around what a reviewer needs
+// when triaging an MR: how many approvals are required/received, who can
+// give them, and who already has.
+type approvalRuleList []*gitlab.MergeRequestApprovalRule
+
+func printApprovalRulesOut(out string, rules ...*gitlab.MergeRequestApprovalRule) error {
+	return printOut(out, approvalRuleList(rules))
+}
+
+func (l approvalRuleList) ToTable() printers.Table {
+	t := printers.Table{Headers: []string{"NAME", "TYPE", "APPROVALS-REQUIRED", "APPROVALS-RECEIVED"}}
+	for _, r := range l {
+		t.Rows = append(t.Rows, []string{
+			r.Name, r.RuleType,
+			fmt.Sprintf("%d", r.ApprovalsRequired),
+			fmt.Sprintf("%d", len(r.ApprovedBy)),
+		})
+	}
+	return t
+}
+
+func (l approvalRuleList) ToWideTable() printers.Table {
+	t := l.ToTable()
+	t.Headers = append(t.Headers, "ELIGIBLE-APPROVERS", "APPROVED-BY")
+	for i, r := range l {
+		t.Rows[i] = append(t.Rows[i], basicUsernames(r.EligibleApprovers), basicUsernames(r.ApprovedBy))
+	}
+	return t
+}
+
+// basicUsernames renders a []*gitlab.BasicUser as a comma-separated list
+// of usernames for table output.
+func basicUsernames(users []*gitlab.BasicUser) string {
+	names := make([]string, len(users))
+	for i, u := range users {
+		names[i] = u.Username
+	}
+	return strings.Join(names, ",")
+}
+
+// mergeRequestList is the printers.TableConvertible adapter for
+// gitlab.MergeRequest.
+type mergeRequestList []*gitlab.MergeRequest
+
+func printMergeRequestsOut(out string, mrs ...*gitlab.MergeRequest) error {
+	return printOut(out, mergeRequestList(mrs))
+}
+
+func (l mergeRequestList) ToTable() printers.Table {
+	t := printers.Table{Headers: []string{"IID", "TITLE", "STATE", "SOURCE", "TARGET"}}
+	for _, mr := range l {
+		t.Rows = append(t.Rows, []string{
+			fmt.Sprintf("%d", mr.IID), mr.Title, mr.State, mr.SourceBranch, mr.TargetBranch,
+		})
+	}
+	return t
+}
+
+func (l mergeRequestList) ToWideTable() printers.Table {
+	t := l.ToTable()
+	t.Headers = append(t.Headers, "WEB-URL")
+	for i, mr := range l {
+		t.Rows[i] = append(t.Rows[i], mr.WebURL)
+	}
+	return t
+}
@@ -0,0 +1,198 @@
+// Copyright © 2018 github.com/devopsctl authors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package printers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// tableStream is the shared Open/WriteItems/Close plumbing for every
+// tabular format (simple, wide, custom-columns): rows are buffered in a
+// tabwriter so columns align across an entire --all run, and the header
+// row is written exactly once, on the first page, rather than once per
+// page.
+type tableStream struct {
+	tw       *tabwriter.Writer
+	wroteHdr bool
+}
+
+func (s *tableStream) open(w io.Writer) error {
+	s.tw = tabwriter.NewWriter(w, 0, 2, 2, ' ', 0)
+	return nil
+}
+
+func (s *tableStream) writeTable(t Table) {
+	if !s.wroteHdr {
+		fmt.Fprintln(s.tw, strings.Join(t.Headers, "\t"))
+		s.wroteHdr = true
+	}
+	for _, row := range t.Rows {
+		fmt.Fprintln(s.tw, strings.Join(row, "\t"))
+	}
+}
+
+func (s *tableStream) close() error {
+	if s.tw == nil {
+		return nil
+	}
+	return s.tw.Flush()
+}
+
+type simplePrinter struct{ tableStream }
+
+func (p *simplePrinter) PrintObj(w io.Writer, obj interface{}) error {
+	if err := p.Open(w); err != nil {
+		return err
+	}
+	if err := p.WriteItems(w, obj); err != nil {
+		return err
+	}
+	return p.Close(w)
+}
+
+func (p *simplePrinter) Open(w io.Writer) error { return p.open(w) }
+
+func (p *simplePrinter) WriteItems(w io.Writer, obj interface{}) error {
+	tc, ok := obj.(TableConvertible)
+	if !ok {
+		return fmt.Errorf("-o simple is not supported for this resource type")
+	}
+	p.writeTable(tc.ToTable())
+	return nil
+}
+
+func (p *simplePrinter) Close(w io.Writer) error { return p.close() }
+
+type widePrinter struct{ tableStream }
+
+func (p *widePrinter) PrintObj(w io.Writer, obj interface{}) error {
+	if err := p.Open(w); err != nil {
+		return err
+	}
+	if err := p.WriteItems(w, obj); err != nil {
+		return err
+	}
+	return p.Close(w)
+}
+
+func (p *widePrinter) Open(w io.Writer) error { return p.open(w) }
+
+func (p *widePrinter) WriteItems(w io.Writer, obj interface{}) error {
+	wtc, ok := obj.(WideTableConvertible)
+	if !ok {
+		return fmt.Errorf("-o wide is not supported for this resource type")
+	}
+	p.writeTable(wtc.ToWideTable())
+	return nil
+}
+
+func (p *widePrinter) Close(w io.Writer) error { return p.close() }
+
+// customColumnsPrinter implements kubectl's `-o custom-columns=NAME:.name,ID:.id`:
+// each column header is paired with a JSONPath expression evaluated against
+// the JSON representation of every item in obj.
+type customColumnsPrinter struct {
+	tableStream
+	headers []string
+	exprs   []*jsonpath.JSONPath
+}
+
+func newCustomColumnsPrinter(spec string) (Printer, error) {
+	if spec == "" {
+		return nil, fmt.Errorf("custom-columns requires a spec, e.g. custom-columns=NAME:.name,ID:.id")
+	}
+	cols := strings.Split(spec, ",")
+	p := &customColumnsPrinter{}
+	for _, col := range cols {
+		parts := strings.SplitN(col, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid custom-columns entry %q, expected NAME:<jsonpath>", col)
+		}
+		jp := jsonpath.New(parts[0])
+		if err := jp.Parse(fmt.Sprintf("{%s}", parts[1])); err != nil {
+			return nil, fmt.Errorf("invalid custom-columns expression for %q: %w", parts[0], err)
+		}
+		p.headers = append(p.headers, parts[0])
+		p.exprs = append(p.exprs, jp)
+	}
+	return p, nil
+}
+
+func (p *customColumnsPrinter) PrintObj(w io.Writer, obj interface{}) error {
+	if err := p.Open(w); err != nil {
+		return err
+	}
+	if err := p.WriteItems(w, obj); err != nil {
+		return err
+	}
+	return p.Close(w)
+}
+
+func (p *customColumnsPrinter) Open(w io.Writer) error { return p.open(w) }
+
+func (p *customColumnsPrinter) WriteItems(w io.Writer, obj interface{}) error {
+	items, err := toItemList(obj)
+	if err != nil {
+		return err
+	}
+	t := Table{Headers: p.headers}
+	for _, item := range items {
+		row := make([]string, len(p.exprs))
+		for i, jp := range p.exprs {
+			var buf strings.Builder
+			if err := jp.Execute(&buf, item); err != nil {
+				row[i] = "<none>"
+				continue
+			}
+			row[i] = buf.String()
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	p.writeTable(t)
+	return nil
+}
+
+func (p *customColumnsPrinter) Close(w io.Writer) error { return p.close() }
+
+// toItemList normalizes obj into a []interface{} of its elements, round
+// tripping through JSON so JSONPath can walk plain maps regardless of the
+// underlying Go type. A non-slice obj becomes a single-item list.
+func toItemList(obj interface{}) ([]interface{}, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+	var asSlice []interface{}
+	if err := json.Unmarshal(b, &asSlice); err == nil {
+		return asSlice, nil
+	}
+	var asOne interface{}
+	if err := json.Unmarshal(b, &asOne); err != nil {
+		return nil, err
+	}
+	return []interface{}{asOne}, nil
+}
@@ -0,0 +1,70 @@
+// Copyright © 2018 github.com/devopsctl authors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package printers
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+type fakeTableRow struct {
+	ID   string
+	Name string
+}
+
+type fakeTableRows []fakeTableRow
+
+func (l fakeTableRows) ToTable() Table {
+	t := Table{Headers: []string{"ID", "NAME"}}
+	for _, r := range l {
+		t.Rows = append(t.Rows, []string{r.ID, r.Name})
+	}
+	return t
+}
+
+// TestSimplePrinterWritesHeaderOnceAcrossPages guards against the --all
+// regression where every page re-emitted its own header row.
+func TestSimplePrinterWritesHeaderOnceAcrossPages(t *testing.T) {
+	var buf bytes.Buffer
+	p := &simplePrinter{}
+
+	if err := p.Open(&buf); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if err := p.WriteItems(&buf, fakeTableRows{{ID: "1", Name: "one"}}); err != nil {
+		t.Fatalf("WriteItems (page 1): %v", err)
+	}
+	if err := p.WriteItems(&buf, fakeTableRows{{ID: "2", Name: "two"}}); err != nil {
+		t.Fatalf("WriteItems (page 2): %v", err)
+	}
+	if err := p.Close(&buf); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	out := buf.String()
+	if n := strings.Count(out, "ID"); n != 1 {
+		t.Errorf("expected the header to appear exactly once, appeared %d times in:\n%s", n, out)
+	}
+	if !strings.Contains(out, "one") || !strings.Contains(out, "two") {
+		t.Errorf("expected rows from both pages, got:\n%s", out)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright © 2018 github.com/devopsctl authors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package printers
+
+// Built-in format names. Formats not listed here (jsonpath, go-template,
+// go-template-file, custom-columns) always carry a "=<param>" suffix, so
+// they have no bare constant.
+const (
+	JSON   = "json"
+	YAML   = "yaml"
+	Simple = "simple"
+	Wide   = "wide"
+)
+
+// BuiltinFormats lists every recognized format name, bare or parameterized,
+// for use in flag help text and validation messages.
+var BuiltinFormats = []string{
+	JSON, YAML, Simple, Wide,
+	"jsonpath=<expr>",
+	"go-template=<tmpl>",
+	"go-template-file=<path>",
+	"custom-columns=<spec>",
+}
+
+// IsKnownFormat reports whether out names a format the DefaultRegistry can
+// build a Printer for, without actually building one (so it's cheap enough
+// for flag validation).
+func IsKnownFormat(out string) bool {
+	name, _ := SplitFormat(out)
+	_, ok := DefaultRegistry.factories[name]
+	return ok
+}
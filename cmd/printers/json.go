@@ -0,0 +1,97 @@
+// Copyright © 2018 github.com/devopsctl authors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package printers
+
+import (
+	"encoding/json"
+	"io"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// jsonPrinter implements StreamPrinter so that printing a result set
+// across several pages (see cmd's --all handling) emits one JSON array
+// for the whole run: Open writes the opening bracket, WriteItems appends
+// each page's items (with a leading comma once a prior item has been
+// written), and Close writes the closing bracket.
+type jsonPrinter struct {
+	wroteAny bool
+}
+
+func (p *jsonPrinter) PrintObj(w io.Writer, obj interface{}) error {
+	if err := p.Open(w); err != nil {
+		return err
+	}
+	if err := p.WriteItems(w, obj); err != nil {
+		return err
+	}
+	return p.Close(w)
+}
+
+func (p *jsonPrinter) Open(w io.Writer) error {
+	_, err := io.WriteString(w, "[")
+	return err
+}
+
+func (p *jsonPrinter) WriteItems(w io.Writer, obj interface{}) error {
+	items, err := toItemList(obj)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		b, err := json.MarshalIndent(item, "  ", "  ")
+		if err != nil {
+			return err
+		}
+		sep := ",\n  "
+		if !p.wroteAny {
+			sep = "\n  "
+		}
+		if _, err := io.WriteString(w, sep); err != nil {
+			return err
+		}
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+		p.wroteAny = true
+	}
+	return nil
+}
+
+func (p *jsonPrinter) Close(w io.Writer) error {
+	suffix := "]\n"
+	if p.wroteAny {
+		suffix = "\n]\n"
+	}
+	_, err := io.WriteString(w, suffix)
+	return err
+}
+
+type yamlPrinter struct{}
+
+func (p *yamlPrinter) PrintObj(w io.Writer, obj interface{}) error {
+	b, err := yaml.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(b)
+	return err
+}
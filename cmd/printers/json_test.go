@@ -0,0 +1,85 @@
+// Copyright © 2018 github.com/devopsctl authors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package printers
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+// TestJSONPrinterStreamsOneArrayAcrossPages guards against the --all
+// regression where every page produced its own top-level JSON array:
+// Open/WriteItems/Close across several pages must still decode as one
+// valid JSON array containing every item.
+func TestJSONPrinterStreamsOneArrayAcrossPages(t *testing.T) {
+	var buf bytes.Buffer
+	p := &jsonPrinter{}
+
+	if err := p.Open(&buf); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if err := p.WriteItems(&buf, []int{1, 2}); err != nil {
+		t.Fatalf("WriteItems (page 1): %v", err)
+	}
+	if err := p.WriteItems(&buf, []int{3}); err != nil {
+		t.Fatalf("WriteItems (page 2): %v", err)
+	}
+	if err := p.Close(&buf); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	var got []int
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not a single valid JSON array: %v\noutput:\n%s", err, buf.String())
+	}
+	want := []int{1, 2, 3}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("item %d: got %d, want %d", i, got[i], want[i])
+		}
+	}
+}
+
+// TestJSONPrinterStreamsEmptyArray guards the zero-page case: Close alone
+// must still close out a syntactically valid (empty) array.
+func TestJSONPrinterStreamsEmptyArray(t *testing.T) {
+	var buf bytes.Buffer
+	p := &jsonPrinter{}
+
+	if err := p.Open(&buf); err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if err := p.Close(&buf); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	var got []int
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not valid JSON: %v\noutput:\n%s", err, buf.String())
+	}
+	if len(got) != 0 {
+		t.Errorf("got %v, want an empty array", got)
+	}
+}
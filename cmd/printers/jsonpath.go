@@ -0,0 +1,62 @@
+// Copyright © 2018 github.com/devopsctl authors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package printers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+type jsonPathPrinter struct {
+	jp *jsonpath.JSONPath
+}
+
+func newJSONPathPrinter(expr string) (Printer, error) {
+	if expr == "" {
+		return nil, fmt.Errorf("jsonpath requires an expression, e.g. jsonpath={.name}")
+	}
+	jp := jsonpath.New("out")
+	if err := jp.Parse(expr); err != nil {
+		return nil, fmt.Errorf("invalid jsonpath expression: %w", err)
+	}
+	return &jsonPathPrinter{jp: jp}, nil
+}
+
+func (p *jsonPathPrinter) PrintObj(w io.Writer, obj interface{}) error {
+	// Round trip through JSON so the expression sees plain maps/slices
+	// instead of having to know the concrete GitLab struct types.
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(b, &generic); err != nil {
+		return err
+	}
+	if err := p.jp.Execute(w, generic); err != nil {
+		return err
+	}
+	_, err = w.Write([]byte("\n"))
+	return err
+}
@@ -0,0 +1,73 @@
+// Copyright © 2018 github.com/devopsctl authors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package printers implements a kubectl-style pluggable output formatter
+// subsystem. Resource-specific code builds a Table view of whatever it
+// wants to print and hands it, along with the raw value, to a Printer
+// obtained from the Registry by output format name.
+package printers
+
+import "io"
+
+// Printer renders a value to w in some output format. obj is the raw
+// value that was requested to be printed (e.g. a []*gitlab.Project); it is
+// used as-is by data formats (json, yaml, jsonpath, go-template) and by
+// custom-columns, and is expected to additionally implement
+// TableConvertible (simple) or WideTableConvertible (wide).
+type Printer interface {
+	PrintObj(w io.Writer, obj interface{}) error
+}
+
+// Table is a generic tabular view of a resource list: one set of column
+// headers and one row per item.
+type Table struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// TableConvertible is implemented by the printFooOut helpers' argument
+// wrapper types so that the simple printer can render them without
+// knowing the underlying GitLab resource type.
+type TableConvertible interface {
+	ToTable() Table
+}
+
+// WideTableConvertible is the --wide equivalent of TableConvertible,
+// surfacing the extra columns that kubectl-style `-o wide` exposes.
+type WideTableConvertible interface {
+	TableConvertible
+	ToWideTable() Table
+}
+
+// StreamPrinter is implemented by formats that need to emit framing -
+// a JSON array's brackets and commas, a table's header row - exactly
+// once across several calls, instead of once per call. A caller driving
+// a paginated list through one of these formats calls Open before the
+// first page, WriteItems once per page, and Close after the last, so
+// e.g. `--all -o json` produces one JSON array instead of one per page.
+// Formats without a streaming contract (go-template, jsonpath, ...) are
+// only usable through the plain Printer interface, against the full,
+// buffered result set.
+type StreamPrinter interface {
+	Printer
+	Open(w io.Writer) error
+	WriteItems(w io.Writer, obj interface{}) error
+	Close(w io.Writer) error
+}
@@ -0,0 +1,82 @@
+// Copyright © 2018 github.com/devopsctl authors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package printers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Factory builds a Printer for a format that takes an optional parameter,
+// e.g. the "<expr>" in "jsonpath=<expr>" or the "<path>" in
+// "go-template-file=<path>". param is empty for formats that take none.
+type Factory func(param string) (Printer, error)
+
+// Registry maps an output format name (the part of -o before "=") to the
+// Factory that builds its Printer.
+type Registry struct {
+	factories map[string]Factory
+}
+
+// NewRegistry returns a Registry pre-populated with every built-in format.
+func NewRegistry() *Registry {
+	r := &Registry{factories: map[string]Factory{}}
+	r.Register(Simple, func(string) (Printer, error) { return &simplePrinter{}, nil })
+	r.Register(Wide, func(string) (Printer, error) { return &widePrinter{}, nil })
+	r.Register(JSON, func(string) (Printer, error) { return &jsonPrinter{}, nil })
+	r.Register(YAML, func(string) (Printer, error) { return &yamlPrinter{}, nil })
+	r.Register("jsonpath", func(expr string) (Printer, error) { return newJSONPathPrinter(expr) })
+	r.Register("go-template", func(tmpl string) (Printer, error) { return newGoTemplatePrinter(tmpl) })
+	r.Register("go-template-file", func(path string) (Printer, error) { return newGoTemplateFilePrinter(path) })
+	r.Register("custom-columns", func(spec string) (Printer, error) { return newCustomColumnsPrinter(spec) })
+	return r
+}
+
+// Register adds or replaces the Factory for name, letting new resource
+// commands (or tests) extend the registry without modifying it.
+func (r *Registry) Register(name string, f Factory) {
+	r.factories[name] = f
+}
+
+// Get parses out (e.g. "simple", "jsonpath={.name}") and returns the
+// Printer for it.
+func (r *Registry) Get(out string) (Printer, error) {
+	name, param := SplitFormat(out)
+	f, ok := r.factories[name]
+	if !ok {
+		return nil, fmt.Errorf("'%s' is not a recognized output format", out)
+	}
+	return f(param)
+}
+
+// SplitFormat splits an -o value into its format name and parameter, e.g.
+// "go-template={{.Name}}" becomes ("go-template", "{{.Name}}"). Formats
+// without a "=" (json, yaml, simple, wide) return an empty parameter.
+func SplitFormat(out string) (name string, param string) {
+	if i := strings.Index(out, "="); i != -1 {
+		return out[:i], out[i+1:]
+	}
+	return out, ""
+}
+
+// DefaultRegistry is the Registry used by the cmd package's printFooOut
+// helpers.
+var DefaultRegistry = NewRegistry()
@@ -0,0 +1,58 @@
+// Copyright © 2018 github.com/devopsctl authors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package printers
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/template"
+)
+
+type goTemplatePrinter struct {
+	tmpl *template.Template
+}
+
+func newGoTemplatePrinter(tmplText string) (Printer, error) {
+	if tmplText == "" {
+		return nil, fmt.Errorf("go-template requires a template, e.g. go-template={{.Name}}")
+	}
+	t, err := template.New("out").Parse(tmplText)
+	if err != nil {
+		return nil, fmt.Errorf("invalid go-template: %w", err)
+	}
+	return &goTemplatePrinter{tmpl: t}, nil
+}
+
+func newGoTemplateFilePrinter(path string) (Printer, error) {
+	if path == "" {
+		return nil, fmt.Errorf("go-template-file requires a path, e.g. go-template-file=./out.tmpl")
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading go-template-file %q: %w", path, err)
+	}
+	return newGoTemplatePrinter(string(b))
+}
+
+func (p *goTemplatePrinter) PrintObj(w io.Writer, obj interface{}) error {
+	return p.tmpl.Execute(w, obj)
+}
@@ -0,0 +1,159 @@
+// Copyright © 2018 github.com/devopsctl authors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package prompt provides minimal terminal prompt primitives. Commands
+// use it as a fallback for required or enum-validated flags that were
+// not supplied on the command line, so a user at an interactive
+// terminal is asked rather than failed outright.
+package prompt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// IsTTY reports whether stdin is attached to a terminal, rather than a
+// pipe or a file.
+func IsTTY() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// Input prompts with label and returns the line the user typed, with
+// leading and trailing whitespace trimmed.
+func Input(label string) (string, error) {
+	return input(os.Stdin, os.Stdout, label)
+}
+
+func input(r io.Reader, w io.Writer, label string) (string, error) {
+	fmt.Fprintf(w, "%s: ", label)
+	scanner := bufio.NewScanner(r)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+	return strings.TrimSpace(scanner.Text()), nil
+}
+
+// Select prompts the user to pick one of options by number and returns
+// the chosen option.
+func Select(label string, options []string) (string, error) {
+	return selectFrom(os.Stdin, os.Stdout, label, options)
+}
+
+func selectFrom(r io.Reader, w io.Writer, label string, options []string) (string, error) {
+	fmt.Fprintln(w, label)
+	for i, opt := range options {
+		fmt.Fprintf(w, "  %d) %s\n", i+1, opt)
+	}
+	scanner := bufio.NewScanner(r)
+	for {
+		fmt.Fprint(w, "Enter a number: ")
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return "", err
+			}
+			return "", io.EOF
+		}
+		choice := strings.TrimSpace(scanner.Text())
+		if n, err := strconv.Atoi(choice); err == nil && n >= 1 && n <= len(options) {
+			return options[n-1], nil
+		}
+		fmt.Fprintf(w, "%q is not a valid choice\n", choice)
+	}
+}
+
+// Editor opens $EDITOR (falling back to vi) on a temporary file seeded
+// with initial, and returns its contents once the editor exits - the
+// same flow `git commit` uses to collect a commit message.
+func Editor(initial string) (string, error) {
+	f, err := os.CreateTemp("", "gitlabctl-*.txt")
+	if err != nil {
+		return "", err
+	}
+	path := f.Name()
+	defer os.Remove(path)
+	if _, err := f.WriteString(initial); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vi"
+	}
+	c := exec.Command(editor, path)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return "", fmt.Errorf("running editor %q: %w", editor, err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(stripCommentLines(string(content))), nil
+}
+
+// stripCommentLines drops every line whose first non-blank character is
+// "#", the same
+// convention git commit uses for the seeded placeholder text it leaves in
+// the editor buffer, so the placeholder never ends up in the submitted
+// value.
+func stripCommentLines(content string) string {
+	lines := strings.Split(content, "\n")
+	kept := lines[:0]
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n")
+}
+
+// Complete prompts for free-form text, listing suggestions (e.g. recently
+// cached project paths) above the prompt. Typing is always free-form;
+// the suggestions are shown for reference only.
+func Complete(label string, suggestions []string) (string, error) {
+	return complete(os.Stdin, os.Stdout, label, suggestions)
+}
+
+func complete(r io.Reader, w io.Writer, label string, suggestions []string) (string, error) {
+	if len(suggestions) > 0 {
+		fmt.Fprintf(w, "%s (known: %s)\n", label, strings.Join(suggestions, ", "))
+	}
+	return input(r, w, label)
+}
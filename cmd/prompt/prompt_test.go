@@ -0,0 +1,73 @@
+// Copyright © 2018 github.com/devopsctl authors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package prompt
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestStripCommentLinesDropsHashPrefixedLines guards the chunk0-7 bug: an
+// editor buffer seeded with "# label\n" and left untouched by the user
+// must not submit that placeholder as real input.
+func TestStripCommentLinesDropsHashPrefixedLines(t *testing.T) {
+	content := "release notes\n# Please enter a description.\n  # indented comment\nmore notes\n"
+	got := stripCommentLines(content)
+	want := "release notes\nmore notes\n"
+	if got != want {
+		t.Errorf("stripCommentLines(%q) = %q, want %q", content, got, want)
+	}
+}
+
+// TestStripCommentLinesAllComments covers the untouched-placeholder case:
+// Editor additionally TrimSpaces this result, so an all-comment buffer
+// ends up empty rather than the literal "# label" text.
+func TestStripCommentLinesAllComments(t *testing.T) {
+	got := strings.TrimSpace(stripCommentLines("# release description\n"))
+	if got != "" {
+		t.Errorf("stripCommentLines of an all-comment buffer = %q, want empty", got)
+	}
+}
+
+func TestInputTrimsWhitespace(t *testing.T) {
+	var out strings.Builder
+	got, err := input(strings.NewReader("  hello world  \n"), &out, "label")
+	if err != nil {
+		t.Fatalf("input: %v", err)
+	}
+	if got != "hello world" {
+		t.Errorf("got %q, want %q", got, "hello world")
+	}
+}
+
+func TestSelectFromRejectsOutOfRangeChoice(t *testing.T) {
+	var out strings.Builder
+	got, err := selectFrom(strings.NewReader("5\n2\n"), &out, "pick one", []string{"a", "b"})
+	if err != nil {
+		t.Fatalf("selectFrom: %v", err)
+	}
+	if got != "b" {
+		t.Errorf("got %q, want %q", got, "b")
+	}
+	if !strings.Contains(out.String(), `"5" is not a valid choice`) {
+		t.Errorf("expected a re-prompt for the out-of-range choice, got:\n%s", out.String())
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright © 2018 github.com/devopsctl authors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	gitlab "github.com/xanzy/go-gitlab"
+
+	cmderrors "github.com/huhouhua/gitlabctl/cmd/errors"
+)
+
+const (
+	rebasePollInterval = 2 * time.Second
+	rebasePollAttempts = 30
+)
+
+var rebaseMrCmd = &cobra.Command{
+	Use:               "mr <id>",
+	Short:             "Rebase a merge request's source branch onto its target branch",
+	SilenceErrors:     true,
+	SilenceUsage:      true,
+	DisableAutoGenTag: true,
+	Args:              cobra.ExactArgs(1),
+	Example: `# rebase MR 42, skipping CI on the rebase commit
+gitlabctl rebase mr 42 --project=groupx/myapp --skip-ci`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mr, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid merge request IID %q: %w", args[0], err)
+		}
+		return runRebaseMr(cmd, mr)
+	},
+}
+
+func init() {
+	rebaseCmd.AddCommand(rebaseMrCmd)
+	addProjectFlag(rebaseMrCmd)
+	rebaseMrCmd.Flags().Bool("skip-ci", false, "Do not trigger a pipeline for the rebase commit")
+}
+
+func runRebaseMr(cmd *cobra.Command, mr int) error {
+	project, err := promptRequiredProject(cmd, "project")
+	if err != nil {
+		return err
+	}
+	skipCI, err := getFlagBool(cmd, "skip-ci")
+	if err != nil {
+		return err
+	}
+	git, err := newGitlabClient()
+	if err != nil {
+		return err
+	}
+	opts := &gitlab.RebaseMergeRequestOptions{SkipCI: gitlab.Bool(skipCI)}
+	if _, err := git.MergeRequests.RebaseMergeRequest(project, mr, opts); err != nil {
+		return fmt.Errorf("starting rebase of merge request !%d: %w", mr, cmderrors.FromAPIError(err))
+	}
+	for attempt := 0; attempt < rebasePollAttempts; attempt++ {
+		m, _, err := git.MergeRequests.GetMergeRequest(project, mr, nil)
+		if err != nil {
+			return cmderrors.FromAPIError(err)
+		}
+		if !m.RebaseInProgress {
+			if m.MergeError != "" {
+				return fmt.Errorf("rebase of merge request !%d failed: %s", mr, m.MergeError)
+			}
+			fmt.Printf("merge request !%d rebased successfully\n", mr)
+			return nil
+		}
+		time.Sleep(rebasePollInterval)
+	}
+	return fmt.Errorf("timed out waiting for merge request !%d to finish rebasing", mr)
+}